@@ -0,0 +1,31 @@
+package hfprop
+
+import "math"
+
+const (
+	// quietFminNight is the nominal fmin in MHz with the Sun below the
+	// horizon on a quiet day.
+	quietFminNight = 1.0
+	// quietFminOverhead is the nominal fmin in MHz with the Sun overhead on
+	// a quiet day.
+	quietFminOverhead = 2.0
+)
+
+// AbsorptionIndex returns the ratio of an observed fmin (MHz) to the value
+// expected on a quiet day at solarZenithDeg. Around 1 is normal. Higher
+// values indicate enhanced D-region absorption, e.g. after a flare.
+//
+// The quiet-day reference follows Appleton's cos^0.75 χ absorption law,
+// scaled between quietFminNight with the Sun below the horizon and
+// quietFminOverhead with the Sun overhead. It returns NaN for a non-positive
+// fmin, which the DIDB uses for missing data.
+func AbsorptionIndex(fmin float64, solarZenithDeg float64) float64 {
+	if fmin <= 0 {
+		return math.NaN()
+	}
+	expected := quietFminNight
+	if c := math.Cos(deg2rad(solarZenithDeg)); c > 0 {
+		expected += (quietFminOverhead - quietFminNight) * math.Pow(c, 0.75)
+	}
+	return fmin / expected
+}
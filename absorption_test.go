@@ -0,0 +1,34 @@
+package hfprop
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAbsorptionIndex(t *testing.T) {
+	tests := []struct {
+		name         string
+		fmin, zenith float64
+		want         float64
+	}{
+		{"quiet, Sun overhead", 2, 0, 1},
+		{"quiet, night", 1, 120, 1},
+		{"night at the horizon", 1, 90, 1},
+		{"doubled fmin overhead", 4, 0, 2},
+	}
+	for _, tt := range tests {
+		if got := AbsorptionIndex(tt.fmin, tt.zenith); !near(got, tt.want, 1e-9) {
+			t.Errorf("%s: AbsorptionIndex(%v, %v) = %v, want %v", tt.name, tt.fmin, tt.zenith, got, tt.want)
+		}
+	}
+	for _, zenith := range []float64{0, 40, 80, 110} {
+		if low, high := AbsorptionIndex(1.5, zenith), AbsorptionIndex(3, zenith); high <= low {
+			t.Errorf("zenith %v: higher fmin gives index %v, not above %v", zenith, high, low)
+		}
+	}
+	for _, fmin := range []float64{0, -1} {
+		if got := AbsorptionIndex(fmin, 30); !math.IsNaN(got) {
+			t.Errorf("AbsorptionIndex(%v, 30) = %v, want NaN", fmin, got)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package hfprop
+
+import "math"
+
+// MinTakeoffAngle returns the lowest practical take-off angle in degrees for
+// a horizontal dipole antennaHeightWavelengths above ground.
+//
+// The model is the ground-reflection array factor of a horizontal antenna
+// over perfectly conducting flat ground, 2·sin(2π·h·sin α). The lower edge of
+// the main lobe is taken as the angle where the factor has fallen 3 dB below
+// its ideal maximum of 2, which gives sin α = 1/(8h). Higher antennas
+// therefore yield lower angles. Antennas below an eighth of a wavelength
+// never reach that level, and for them (and non-positive heights) the result
+// is 90.
+func MinTakeoffAngle(antennaHeightWavelengths float64) float64 {
+	if antennaHeightWavelengths <= 0 {
+		return 90
+	}
+	s := 1 / (8 * antennaHeightWavelengths)
+	if s >= 1 {
+		return 90
+	}
+	return rad2deg(math.Asin(s))
+}
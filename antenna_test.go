@@ -0,0 +1,32 @@
+package hfprop
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinTakeoffAngle(t *testing.T) {
+	tests := []struct {
+		height, want float64
+	}{
+		{-1, 90}, {0, 90}, {0.1, 90}, {0.125, 90},
+		{0.25, 30}, {0.5, 14.48}, {1, 7.18}, {2, 3.58},
+	}
+	for _, tt := range tests {
+		if got := MinTakeoffAngle(tt.height); !near(got, tt.want, 0.01) {
+			t.Errorf("MinTakeoffAngle(%v) = %v, want %v", tt.height, got, tt.want)
+		}
+	}
+	prev := 90.0
+	for _, h := range []float64{0.2, 0.3, 0.5, 0.75, 1, 1.5, 2, 4} {
+		got := MinTakeoffAngle(h)
+		if got >= prev {
+			t.Errorf("MinTakeoffAngle(%v) = %v, want below %v for a lower antenna", h, got, prev)
+		}
+		// The array factor is 3 dB below its maximum at the returned angle.
+		if af := 2 * math.Sin(2*math.Pi*h*math.Sin(deg2rad(got))); !near(af, math.Sqrt2, 1e-9) {
+			t.Errorf("MinTakeoffAngle(%v): array factor %v at %v degrees", h, af, got)
+		}
+		prev = got
+	}
+}
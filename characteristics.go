@@ -0,0 +1,102 @@
+package hfprop
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownCharacteristic is wrapped by the errors returned from
+// ValidateCharacteristics for names not in the DIDBase characteristic set.
+var ErrUnknownCharacteristic = errors.New("unknown ionospheric characteristic")
+
+// Characteristic is the DIDBase name of an ionospheric characteristic, such
+// as "foF2" or "hmF2".
+type Characteristic string
+
+// characteristics lists the ionospheric characteristics served by the GIRO
+// DIDBase, in documentation order, with a short description of each. Names
+// are case-sensitive.
+var characteristics = []struct {
+	name        Characteristic
+	description string
+}{
+	{"foF2", "F2 layer critical frequency (MHz)"},
+	{"foF1", "F1 layer critical frequency (MHz)"},
+	{"foE", "E layer critical frequency (MHz)"},
+	{"foEs", "Es layer top frequency (MHz)"},
+	{"fbEs", "Es layer blanketing frequency (MHz)"},
+	{"foEa", "Auroral E layer critical frequency (MHz)"},
+	{"foP", "Highest ordinary wave critical frequency of F region patch trace (MHz)"},
+	{"fxI", "Maximum frequency of F trace (MHz)"},
+	{"fmin", "Minimum frequency of ionogram echoes (MHz)"},
+	{"fminF", "Minimum frequency of F layer echoes (MHz)"},
+	{"fminE", "Minimum frequency of E layer echoes (MHz)"},
+	{"fminEs", "Minimum frequency of Es layer echoes (MHz)"},
+	{"MUFD", "Maximum usable frequency for ground distance D (MHz)"},
+	{"MD", "MUF(D)/foF2 propagation factor"},
+	{"hmF2", "Peak height of F2 layer (km)"},
+	{"hmF1", "Peak height of F1 layer (km)"},
+	{"hmE", "Peak height of E layer (km)"},
+	{"hF", "Minimum virtual height of F trace (km)"},
+	{"hF2", "Minimum virtual height of F2 trace (km)"},
+	{"hE", "Minimum virtual height of E trace (km)"},
+	{"hEs", "Minimum virtual height of Es trace (km)"},
+	{"hEa", "Minimum virtual height of auroral E trace (km)"},
+	{"yF2", "Half thickness of F2 layer (km)"},
+	{"yF1", "Half thickness of F1 layer (km)"},
+	{"yE", "Half thickness of E layer (km)"},
+	{"scaleF2", "Scale height at F2 peak (km)"},
+	{"B0", "IRI thickness parameter (km)"},
+	{"B1", "IRI profile shape parameter"},
+	{"D1", "IRI profile shape parameter for F1 layer"},
+	{"TEC", "Total electron content (TECU)"},
+	{"FF", "Frequency spread between fxF2 and fxI (MHz)"},
+	{"FE", "Frequency spread beyond foE (MHz)"},
+	{"QF", "Average range spread of F trace (km)"},
+	{"QE", "Average range spread of E trace (km)"},
+	{"TypeEs", "Type of Es layer"},
+}
+
+// SupportedCharacteristics returns every characteristic served by the GIRO
+// DIDBase, in documentation order.
+func SupportedCharacteristics() []Characteristic {
+	all := make([]Characteristic, len(characteristics))
+	for i, c := range characteristics {
+		all[i] = c.name
+	}
+	return all
+}
+
+// Description returns a short human-readable description of c, including
+// its unit where it has one, or an empty string if c is not supported.
+func (c Characteristic) Description() string {
+	for _, k := range characteristics {
+		if k.name == c {
+			return k.description
+		}
+	}
+	return ""
+}
+
+// ValidateCharacteristics checks each name in params against the DIDBase
+// characteristic set and returns one error, wrapping
+// ErrUnknownCharacteristic, per unknown name. It returns nil when every name
+// is valid.
+func ValidateCharacteristics(params []string) []error {
+	var errs []error
+	for _, p := range params {
+		if !knownCharacteristic(p) {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownCharacteristic, p))
+		}
+	}
+	return errs
+}
+
+func knownCharacteristic(name string) bool {
+	for _, c := range characteristics {
+		if c.name == Characteristic(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package hfprop
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateCharacteristics(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  []string
+		invalid []string
+	}{
+		{"none", nil, nil},
+		{"all valid", []string{"foF2", "hmF2", "MUFD", "TypeEs"}, nil},
+		{"mixed", []string{"foF2", "fof2", "hmF2", "hmf2", "MD", "M3000F2"}, []string{"fof2", "hmf2", "M3000F2"}},
+		{"all invalid", []string{"", "foo"}, []string{"", "foo"}},
+	}
+	for _, tt := range tests {
+		errs := ValidateCharacteristics(tt.params)
+		if tt.invalid == nil && errs != nil {
+			t.Errorf("%s: errors %v, want nil", tt.name, errs)
+			continue
+		}
+		if len(errs) != len(tt.invalid) {
+			t.Errorf("%s: %d errors %v, want %d", tt.name, len(errs), errs, len(tt.invalid))
+			continue
+		}
+		for i, err := range errs {
+			if !errors.Is(err, ErrUnknownCharacteristic) {
+				t.Errorf("%s: error %v does not wrap ErrUnknownCharacteristic", tt.name, err)
+			}
+			if want := `"` + tt.invalid[i] + `"`; !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: error %v does not name %s", tt.name, err, want)
+			}
+		}
+	}
+}
+
+func TestSupportedCharacteristics(t *testing.T) {
+	all := SupportedCharacteristics()
+	if len(all) != 35 {
+		t.Fatalf("%d characteristics, want the 35 documented", len(all))
+	}
+	if all[0] != "foF2" || all[len(all)-1] != "TypeEs" {
+		t.Errorf("first and last = %q, %q, want documentation order", all[0], all[len(all)-1])
+	}
+	seen := make(map[Characteristic]bool)
+	for _, c := range all {
+		if seen[c] {
+			t.Errorf("%q listed twice", c)
+		}
+		seen[c] = true
+		if c.Description() == "" {
+			t.Errorf("%q has no description", c)
+		}
+		if errs := ValidateCharacteristics([]string{string(c)}); errs != nil {
+			t.Errorf("%q fails validation: %v", c, errs)
+		}
+	}
+	tests := []struct {
+		c    Characteristic
+		want string
+	}{
+		{"foF2", "F2 layer critical frequency (MHz)"},
+		{"hmF2", "Peak height of F2 layer (km)"},
+		{"MUFD", "Maximum usable frequency for ground distance D (MHz)"},
+		{"fof2", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Description(); got != tt.want {
+			t.Errorf("%q.Description() = %q, want %q", tt.c, got, tt.want)
+		}
+	}
+	all[0] = "changed"
+	if SupportedCharacteristics()[0] != "foF2" {
+		t.Error("SupportedCharacteristics returns a shared slice")
+	}
+}
@@ -0,0 +1,75 @@
+package hfprop
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrAboveMUF is returned when a frequency exceeds the MUF at every usable
+// take-off angle, so that no single-hop sky-wave coverage exists.
+var ErrAboveMUF = errors.New("frequency above the MUF at every usable take-off angle")
+
+// MaxSingleHopRange returns the ground distance in km of a single hop off a
+// layer at hmF2 for a ray leaving the ground at minTOADegrees, the longest
+// hop an antenna with that lowest usable elevation can achieve.
+func MaxSingleHopRange(hmF2, minTOADegrees float64) float64 {
+	beta := deg2rad(math.Max(0, math.Min(90, minTOADegrees)))
+	// Angle of incidence at the layer.
+	phi := math.Asin(EarthRadiusKm * math.Cos(beta) / (EarthRadiusKm + hmF2))
+	return 2 * EarthRadiusKm * (math.Pi/2 - beta - phi)
+}
+
+// SkipDistance returns the skip distance in km for frequencyMHz: the
+// shortest single-hop ground distance at which an F2 layer with critical
+// frequency foF2 at hmF2 reflects the frequency. It is 0 when the frequency
+// does not exceed foF2, since the layer then reflects it even vertically.
+// ErrAboveMUF is returned if the frequency passes through the layer at every
+// take-off angle down to DefaultMinTOADegrees.
+func SkipDistance(foF2, hmF2, frequencyMHz float64) (km float64, err error) {
+	return skipDistance(foF2, hmF2, frequencyMHz, DefaultMinTOADegrees)
+}
+
+// CoverageFootprint returns the single-hop sky-wave coverage ring for
+// frequencyMHz off an F2 layer with critical frequency foF2 at hmF2,
+// assuming an antenna with a lowest usable take-off angle of
+// DefaultMinTOADegrees. See CoverageFootprintForAntenna.
+func CoverageFootprint(foF2, hmF2, frequencyMHz float64) (innerKm, outerKm float64, err error) {
+	return CoverageFootprintForAntenna(foF2, hmF2, frequencyMHz, DefaultMinTOADegrees)
+}
+
+// CoverageFootprintForAntenna returns the single-hop sky-wave coverage ring
+// for frequencyMHz off an F2 layer with critical frequency foF2 at hmF2, for
+// an antenna whose lowest usable take-off angle is minTOADegrees (see
+// MinTakeoffAngle). The inner edge is the skip distance and the outer edge is
+// the single-hop range at minTOADegrees, both in km. As the frequency rises
+// the inner edge moves out and the ring narrows until ErrAboveMUF is
+// returned.
+func CoverageFootprintForAntenna(foF2, hmF2, frequencyMHz, minTOADegrees float64) (innerKm, outerKm float64, err error) {
+	innerKm, err = skipDistance(foF2, hmF2, frequencyMHz, minTOADegrees)
+	if err != nil {
+		return 0, 0, err
+	}
+	return innerKm, MaxSingleHopRange(hmF2, minTOADegrees), nil
+}
+
+// skipDistance is SkipDistance with the take-off angle floor minTOADegrees
+// bounding the longest hop searched.
+func skipDistance(foF2, hmF2, frequencyMHz, minTOADegrees float64) (float64, error) {
+	if frequencyMHz <= foF2 {
+		return 0, nil
+	}
+	lo, hi := 0.0, MaxSingleHopRange(hmF2, minTOADegrees)
+	muf := func(d float64) float64 { return foF2 * secantFactor(TOA(d, hmF2), hmF2) }
+	if muf(hi) < frequencyMHz {
+		return 0, ErrAboveMUF
+	}
+	for i := 0; i < 100 && hi-lo > 1e-3; i++ {
+		mid := (lo + hi) / 2
+		if muf(mid) >= frequencyMHz {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
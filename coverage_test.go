@@ -0,0 +1,71 @@
+package hfprop
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMaxSingleHopRange(t *testing.T) {
+	tests := []struct {
+		minTOA, want float64
+	}{
+		{0, singleHopHorizon(300)},
+		{90, 0},
+	}
+	for _, tt := range tests {
+		if got := MaxSingleHopRange(300, tt.minTOA); !near(got, tt.want, 1e-6) {
+			t.Errorf("MaxSingleHopRange(300, %v) = %v, want %v", tt.minTOA, got, tt.want)
+		}
+	}
+	for _, angle := range []float64{3, 10, 30} {
+		if got := TOA(MaxSingleHopRange(300, angle), 300); !near(got, angle, 1e-6) {
+			t.Errorf("TOA(MaxSingleHopRange(300, %v)) = %v", angle, got)
+		}
+	}
+}
+
+func TestCoverageFootprint(t *testing.T) {
+	const foF2, hmF2 = 7.0, 300.0
+	prevInner, prevWidth := -1.0, 1e9
+	for _, f := range []float64{5, 7, 10, 14, 18, 21} {
+		inner, outer, err := CoverageFootprint(foF2, hmF2, f)
+		if err != nil {
+			t.Fatalf("%v MHz: %v", f, err)
+		}
+		if inner >= outer {
+			t.Errorf("%v MHz: inner edge %v not inside outer edge %v", f, inner, outer)
+		}
+		if inner < prevInner || outer-inner > prevWidth {
+			t.Errorf("%v MHz: ring %v-%v should narrow as frequency rises", f, inner, outer)
+		}
+		if f > foF2 && !near(foF2*secantFactor(TOA(inner, hmF2), hmF2), f, 1e-3) {
+			t.Errorf("%v MHz: MUF at the skip distance is not the frequency", f)
+		}
+		prevInner, prevWidth = inner, outer-inner
+	}
+	if _, _, err := CoverageFootprint(foF2, hmF2, 30); !errors.Is(err, ErrAboveMUF) {
+		t.Errorf("30 MHz: err = %v, want ErrAboveMUF", err)
+	}
+}
+
+func TestCoverageFootprintForAntenna(t *testing.T) {
+	const foF2, hmF2, f = 7.0, 300.0, 10.0
+	_, tall, err := CoverageFootprintForAntenna(foF2, hmF2, f, MinTakeoffAngle(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, short, err := CoverageFootprintForAntenna(foF2, hmF2, f, MinTakeoffAngle(0.25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if short >= tall {
+		t.Errorf("a lower antenna should reach less far: %v km vs %v km", short, tall)
+	}
+	// 23.5 MHz needs an angle below 3 degrees: only a low floor covers it.
+	if _, _, err := CoverageFootprintForAntenna(foF2, hmF2, 23.5, 0.5); err != nil {
+		t.Errorf("23.5 MHz with a 0.5 degree floor: %v", err)
+	}
+	if _, _, err := CoverageFootprint(foF2, hmF2, 23.5); !errors.Is(err, ErrAboveMUF) {
+		t.Errorf("23.5 MHz with the default floor: err = %v, want ErrAboveMUF", err)
+	}
+}
@@ -0,0 +1,20 @@
+package hfprop
+
+import "math"
+
+// speedOfLight is the speed of light in vacuum in m/s.
+const speedOfLight = 299792458
+
+// DopplerShift returns the Doppler offset in Hz imposed on a signal at
+// frequencyMHz by a reflection height changing at heightChangeRateMPerS
+// (positive when the layer rises) on a path leaving the ground at
+// toaDegrees.
+//
+// A flat-Earth mirror model is used: the length of a hop over a fixed ground
+// distance changes at 2·sin(toa) times the height change rate, so the offset
+// is −2·f·sin(toa)·(dh/dt)/c. A rising layer lengthens the path and gives a
+// negative shift. A descending layer gives a positive one.
+func DopplerShift(frequencyMHz, heightChangeRateMPerS, toaDegrees float64) float64 {
+	pathRate := 2 * math.Sin(deg2rad(toaDegrees)) * heightChangeRateMPerS
+	return -frequencyMHz * 1e6 * pathRate / speedOfLight
+}
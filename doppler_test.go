@@ -0,0 +1,27 @@
+package hfprop
+
+import "testing"
+
+func TestDopplerShift(t *testing.T) {
+	tests := []struct {
+		name            string
+		frequency, rate float64
+		toa             float64
+		want            float64
+	}{
+		// At vertical incidence the path changes at twice the height rate.
+		{"rising, vertical", 10, 100, 90, -2 * 10e6 * 100 / speedOfLight},
+		{"descending, vertical", 10, -100, 90, 2 * 10e6 * 100 / speedOfLight},
+		{"rising, 30 degrees", 14, 50, 30, -14e6 * 50 / speedOfLight},
+		{"stationary layer", 14, 0, 30, 0},
+		{"grazing", 14, 50, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := DopplerShift(tt.frequency, tt.rate, tt.toa); !near(got, tt.want, 1e-9) {
+			t.Errorf("%s: DopplerShift = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if rising := DopplerShift(7, 20, 45); rising >= 0 {
+		t.Errorf("a rising layer should give a negative shift, got %v", rising)
+	}
+}
@@ -0,0 +1,49 @@
+package hfprop
+
+import "math"
+
+// blanketingRatio is the fbEs/foEs ratio at or above which a sporadic-E layer
+// is considered blanketing.
+const blanketingRatio = 0.9
+
+// esOpeningScale is the spread of the logistic curve used by
+// EsOpeningProbability, in units of the EsMUF/frequency ratio.
+const esOpeningScale = 0.1
+
+// IsBlanketingEs reports whether a sporadic-E layer with top frequency foEs
+// and blanketing frequency fbEs blankets the layers above it. The layer
+// becomes opaque as fbEs approaches foEs, and it is considered blanketing
+// once fbEs reaches 90% of foEs. Non-positive values, which the DIDB uses for
+// missing data, never count as blanketing.
+func IsBlanketingEs(foEs, fbEs float64) bool {
+	if foEs <= 0 || fbEs <= 0 {
+		return false
+	}
+	return fbEs >= blanketingRatio*foEs
+}
+
+// EsMUF returns the maximum usable frequency in MHz for a single sporadic-E
+// hop of distanceKm, reflecting at ELayerHeightKm, given the Es layer's top
+// frequency foEs. It returns 0 for paths beyond the single-hop Es range
+// (about 2350 km), which no single Es hop supports.
+func EsMUF(foEs, distanceKm float64) float64 {
+	toa, err := TOAChecked(distanceKm, ELayerHeightKm)
+	if err != nil {
+		return 0
+	}
+	return foEs * secantFactor(toa, ELayerHeightKm)
+}
+
+// EsOpeningProbability returns a 0–1 likelihood that sporadic E with top
+// frequency foEs supports frequencyMHz over distanceKm. It is a logistic
+// function of the ratio EsMUF/frequency: 0.5 when the Es MUF equals the
+// frequency, rising towards 1 as the Es MUF exceeds it. Non-positive inputs,
+// and paths beyond the single-hop Es range, give 0.
+func EsOpeningProbability(foEs float64, frequencyMHz, distanceKm float64) float64 {
+	muf := EsMUF(foEs, distanceKm)
+	if foEs <= 0 || frequencyMHz <= 0 || muf == 0 {
+		return 0
+	}
+	margin := muf/frequencyMHz - 1
+	return 1 / (1 + math.Exp(-margin/esOpeningScale))
+}
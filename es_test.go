@@ -0,0 +1,64 @@
+package hfprop
+
+import "testing"
+
+func TestIsBlanketingEs(t *testing.T) {
+	tests := []struct {
+		name       string
+		foEs, fbEs float64
+		want       bool
+	}{
+		{"equal", 5, 5, true},
+		{"at the threshold", 5, 4.5, true},
+		{"just below the threshold", 5, 4.49, false},
+		{"disparate", 8, 3, false},
+		{"fbEs above foEs", 5, 5.2, true},
+		{"missing foEs", 0, 4, false},
+		{"missing fbEs", 5, -1, false},
+	}
+	for _, tt := range tests {
+		if got := IsBlanketingEs(tt.foEs, tt.fbEs); got != tt.want {
+			t.Errorf("%s: IsBlanketingEs(%v, %v) = %v, want %v", tt.name, tt.foEs, tt.fbEs, got, tt.want)
+		}
+	}
+}
+
+func TestEsOpeningProbability(t *testing.T) {
+	const f, d = 50.1, 1500.0
+	// foEs at which the Es MUF equals the frequency.
+	needed := f / EsMUF(1, d)
+	if p := EsOpeningProbability(needed, f, d); !near(p, 0.5, 1e-9) {
+		t.Errorf("at the needed foEs %v: %v, want 0.5", needed, p)
+	}
+	prev := 0.0
+	for _, foEs := range []float64{0.5 * needed, 0.8 * needed, needed, 1.2 * needed, 1.5 * needed} {
+		p := EsOpeningProbability(foEs, f, d)
+		if p <= prev || p >= 1 {
+			t.Errorf("foEs %v: probability %v, want in (%v, 1)", foEs, p, prev)
+		}
+		prev = p
+	}
+	if p := EsOpeningProbability(2*needed, f, d); p < 0.99 {
+		t.Errorf("twice the needed foEs: %v, want near 1", p)
+	}
+	for _, tt := range []struct{ foEs, f float64 }{{0, f}, {-1, f}, {10, 0}} {
+		if p := EsOpeningProbability(tt.foEs, tt.f, d); p != 0 {
+			t.Errorf("EsOpeningProbability(%v, %v) = %v, want 0", tt.foEs, tt.f, p)
+		}
+	}
+}
+
+func TestEsMUFBeyondHorizon(t *testing.T) {
+	horizon := singleHopHorizon(ELayerHeightKm)
+	if got := EsMUF(10, horizon-10); got < 50 {
+		t.Errorf("EsMUF just inside the Es horizon = %v, want a grazing-hop MUF", got)
+	}
+	for _, d := range []float64{horizon + 10, 3000, 5000, 10000} {
+		if got := EsMUF(10, d); got != 0 {
+			t.Errorf("EsMUF(10, %v) = %v, want 0 beyond the Es horizon", d, got)
+		}
+		if p := EsOpeningProbability(10, 50, d); p != 0 {
+			t.Errorf("EsOpeningProbability(10, 50, %v) = %v, want 0 beyond the Es horizon", d, p)
+		}
+	}
+}
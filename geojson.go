@@ -0,0 +1,107 @@
+package hfprop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidHops is returned by PathGeoJSON when the hop count is less than
+// one.
+var ErrInvalidHops = errors.New("hop count must be at least 1")
+
+// pathGeoJSONPoints is the number of vertices used for the path line.
+const pathGeoJSONPoints = 101
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// PathGeoJSON returns a GeoJSON FeatureCollection describing the great-circle
+// path from one station to another, split into hops of equal length. The
+// collection holds, in order:
+//   - the path, as a LineString, or a MultiLineString split at the
+//     antimeridian when the path crosses it;
+//   - the two stations as Points, with role "from" and "to";
+//   - one Point per hop at its reflection point (the hop midpoint).
+//
+// The path feature carries the hop count, the total distance and the
+// per-hop take-off angle for reflection at F2LayerHeightKm. Each reflection
+// point carries its hop number and take-off angle. ErrInvalidHops is returned
+// for hops below 1. ErrUnreachable is returned when the hops are too long for
+// a single reflection each.
+func PathGeoJSON(from, to Station, hops int) ([]byte, error) {
+	if hops < 1 {
+		return nil, ErrInvalidHops
+	}
+	distance := GreatCircleDistance(from, to)
+	toa, err := TOAChecked(distance/float64(hops), F2LayerHeightKm)
+	if err != nil {
+		return nil, fmt.Errorf("%d hops over %.0f km: %w", hops, distance, err)
+	}
+	features := []geoJSONFeature{
+		pathFeature(GreatCirclePolyline(from, to, pathGeoJSONPoints), map[string]any{
+			"hops":       hops,
+			"distanceKm": distance,
+			"toaDegrees": toa,
+		}),
+		pointFeature([2]float64{from.Latitude, normalizeLongitude(from.Longitude)}, stationProperties(from, "from")),
+		pointFeature([2]float64{to.Latitude, normalizeLongitude(to.Longitude)}, stationProperties(to, "to")),
+	}
+	for hop := 1; hop <= hops; hop++ {
+		fraction := (float64(hop) - 0.5) / float64(hops)
+		features = append(features, pointFeature(intermediatePoint(from, to, fraction), map[string]any{
+			"role":       "reflection",
+			"hop":        hop,
+			"toaDegrees": toa,
+		}))
+	}
+	return json.Marshal(geoJSONFeatureCollection{Type: "FeatureCollection", Features: features})
+}
+
+// pathFeature returns a LineString feature for line ([latitude, longitude]
+// vertices), or a MultiLineString split wherever consecutive vertices jump
+// across the antimeridian.
+func pathFeature(line [][2]float64, properties map[string]any) geoJSONFeature {
+	var parts [][][2]float64
+	var part [][2]float64
+	for i, v := range line {
+		if i > 0 && math.Abs(v[1]-line[i-1][1]) > 180 {
+			parts = append(parts, part)
+			part = nil
+		}
+		part = append(part, [2]float64{v[1], v[0]})
+	}
+	parts = append(parts, part)
+	geometry := geoJSONGeometry{Type: "LineString", Coordinates: parts[0]}
+	if len(parts) > 1 {
+		geometry = geoJSONGeometry{Type: "MultiLineString", Coordinates: parts}
+	}
+	return geoJSONFeature{Type: "Feature", Geometry: geometry, Properties: properties}
+}
+
+// pointFeature returns a Point feature at v ([latitude, longitude]).
+func pointFeature(v [2]float64, properties map[string]any) geoJSONFeature {
+	return geoJSONFeature{
+		Type:       "Feature",
+		Geometry:   geoJSONGeometry{Type: "Point", Coordinates: [2]float64{v[1], v[0]}},
+		Properties: properties,
+	}
+}
+
+func stationProperties(s Station, role string) map[string]any {
+	return map[string]any{"role": role, "name": s.Name, "ursiCode": s.URSICode}
+}
@@ -0,0 +1,74 @@
+package hfprop
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type testFeatureCollection struct {
+	Type     string `json:"type"`
+	Features []struct {
+		Type     string `json:"type"`
+		Geometry struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	} `json:"features"`
+}
+
+func TestPathGeoJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Station
+		hops     int
+		lineType string
+	}{
+		{"Gothenburg to New York", NewStation("gbg", 57.7, 11.97), NewStation("nyc", 40.7, -74.0), 2, "LineString"},
+		{"Tokyo to San Francisco", NewStation("tyo", 35.7, 139.7), NewStation("sf", 37.8, -122.4), 3, "MultiLineString"},
+	}
+	for _, tt := range tests {
+		b, err := PathGeoJSON(tt.from, tt.to, tt.hops)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var fc testFeatureCollection
+		if err := json.Unmarshal(b, &fc); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", tt.name, err)
+		}
+		if fc.Type != "FeatureCollection" || len(fc.Features) != 3+tt.hops {
+			t.Fatalf("%s: type %q with %d features, want FeatureCollection with %d", tt.name, fc.Type, len(fc.Features), 3+tt.hops)
+		}
+		if got := fc.Features[0].Geometry.Type; got != tt.lineType {
+			t.Errorf("%s: path geometry %q, want %q", tt.name, got, tt.lineType)
+		}
+		if got := fc.Features[0].Properties["hops"]; got != float64(tt.hops) {
+			t.Errorf("%s: hops property %v", tt.name, got)
+		}
+		var from [2]float64
+		if err := json.Unmarshal(fc.Features[1].Geometry.Coordinates, &from); err != nil || from != [2]float64{tt.from.Longitude, tt.from.Latitude} {
+			t.Errorf("%s: from point %v, %v", tt.name, from, err)
+		}
+		for i, f := range fc.Features[3:] {
+			var p [2]float64
+			if err := json.Unmarshal(f.Geometry.Coordinates, &p); err != nil {
+				t.Fatal(err)
+			}
+			want := intermediatePoint(tt.from, tt.to, (float64(i)+0.5)/float64(tt.hops))
+			if f.Geometry.Type != "Point" || f.Properties["role"] != "reflection" || !near(p[0], want[1], 1e-9) || !near(p[1], want[0], 1e-9) {
+				t.Errorf("%s: reflection point %d = %v %v", tt.name, i+1, p, f.Properties)
+			}
+		}
+	}
+}
+
+func TestPathGeoJSONErrors(t *testing.T) {
+	from, to := NewStation("gbg", 57.7, 11.97), NewStation("nyc", 40.7, -74.0)
+	if _, err := PathGeoJSON(from, to, 0); !errors.Is(err, ErrInvalidHops) {
+		t.Errorf("zero hops: %v", err)
+	}
+	if _, err := PathGeoJSON(from, to, 1); !errors.Is(err, ErrUnreachable) {
+		t.Errorf("one hop over %.0f km: %v", GreatCircleDistance(from, to), err)
+	}
+}
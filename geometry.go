@@ -0,0 +1,146 @@
+// Package hfprop provides HF propagation helpers built around ionosonde data
+// from the Lowell GIRO Data Center (LGDC).
+package hfprop
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrUnreachable is returned when a path is longer than a single hop off a
+// layer at the given height can cover, i.e. the take-off angle would be
+// negative.
+var ErrUnreachable = errors.New("distance unreachable in a single hop at this reflection height")
+
+// CentralAngle returns the great-circle angular separation in degrees
+// between two points given as latitude and longitude in decimal degrees. The
+// haversine formula is used so that short separations remain accurate. Scale
+// the angle (in radians) by the Earth radius to obtain a distance.
+func CentralAngle(lat1, lon1, lat2, lon2 float64) (degrees float64) {
+	phi1, phi2 := deg2rad(lat1), deg2rad(lat2)
+	dPhi := phi2 - phi1
+	dLambda := deg2rad(lon2 - lon1)
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	a = math.Min(1, math.Max(0, a))
+	return rad2deg(2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a)))
+}
+
+// InitialBearing returns the initial true bearing in degrees [0, 360) of the
+// great circle from the first point to the second, both given as latitude
+// and longitude in decimal degrees.
+func InitialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := deg2rad(lat1), deg2rad(lat2)
+	dLambda := deg2rad(lon2 - lon1)
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+	return normalizeBearing(rad2deg(math.Atan2(y, x)))
+}
+
+// LongPath returns the distance in km and initial bearing in degrees of the
+// long way around the great circle between two points, i.e. the Earth's
+// circumference minus the short path, heading opposite to the short-path
+// bearing.
+func LongPath(lat1, lon1, lat2, lon2 float64) (km, bearing float64) {
+	short := deg2rad(CentralAngle(lat1, lon1, lat2, lon2)) * EarthRadiusKm
+	return 2*math.Pi*EarthRadiusKm - short, normalizeBearing(InitialBearing(lat1, lon1, lat2, lon2) + 180)
+}
+
+// Antipode returns the latitude and longitude, in decimal degrees, of the
+// point diametrically opposite lat, lon. The longitude is in (-180, 180].
+func Antipode(lat, lon float64) (float64, float64) {
+	return -lat, normalizeLongitude(lon + 180)
+}
+
+// TOA returns the take-off angle in degrees for a single-hop path of
+// distanceKm reflecting at heightKm over a spherical Earth. The result is
+// clamped to the range [0, 90]: zero or negative distances give 90 (vertical
+// incidence) and paths beyond the single-hop horizon give 0. Use TOAChecked
+// to tell a grazing path from an unreachable one.
+func TOA(distanceKm, heightKm float64) float64 {
+	toa, _ := TOAChecked(distanceKm, heightKm)
+	return toa
+}
+
+// TOAForHeight returns the take-off angle in degrees for a single-hop path of
+// distanceKm reflecting at an explicitly chosen reflectionHeightKm, such as
+// about 110 km for E or sporadic-E reflections. It is identical to TOA and
+// exists to make the intent clear when the height is not hmF2.
+func TOAForHeight(distanceKm, reflectionHeightKm float64) float64 {
+	return TOA(distanceKm, reflectionHeightKm)
+}
+
+// TOASweep returns the take-off angle in degrees, as computed by TOA, for
+// each of distances (km) with reflection at hmF2. The result has the same
+// length and order as distances, and is empty for an empty input.
+func TOASweep(hmF2 float64, distances []float64) []float64 {
+	angles := make([]float64, len(distances))
+	for i, d := range distances {
+		angles[i] = TOA(d, hmF2)
+	}
+	return angles
+}
+
+// TOAChecked is like TOA but returns ErrUnreachable, together with the
+// clamped angle of 0, when distanceKm cannot be covered in a single hop off a
+// layer at heightKm.
+func TOAChecked(distanceKm, heightKm float64) (float64, error) {
+	if distanceKm <= 0 {
+		return 90, nil
+	}
+	// Half the central angle between the end points of the hop.
+	theta := distanceKm / (2 * EarthRadiusKm)
+	toa := rad2deg(math.Atan2(math.Cos(theta)-EarthRadiusKm/(EarthRadiusKm+heightKm), math.Sin(theta)))
+	switch {
+	case toa < 0 || math.IsNaN(toa):
+		return 0, ErrUnreachable
+	case toa > 90:
+		return 90, nil
+	}
+	return toa, nil
+}
+
+// RadioHorizon returns the distance in km to the radio horizon for an
+// antenna antennaHeightM metres above ground, √(2·k·R·h), using the standard
+// 4/3 effective Earth radius factor k for atmospheric refraction. That is
+// about 4.12·√h km for h in metres.
+func RadioHorizon(antennaHeightM float64) float64 {
+	if antennaHeightM <= 0 {
+		return 0
+	}
+	const k = 4.0 / 3
+	return math.Sqrt(2 * k * EarthRadiusKm * antennaHeightM / 1000)
+}
+
+// singleHopHorizon returns the ground distance in km at which a ray
+// reflected at heightKm leaves and returns to the ground tangentially, i.e.
+// the longest single hop at a zero take-off angle.
+func singleHopHorizon(heightKm float64) float64 {
+	return 2 * EarthRadiusKm * math.Acos(EarthRadiusKm/(EarthRadiusKm+heightKm))
+}
+
+// normalizeBearing maps an angle in degrees onto [0, 360).
+func normalizeBearing(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	if deg >= 360 {
+		// Adding 360 to a tiny negative angle rounds up to 360.
+		deg = 0
+	}
+	return deg
+}
+
+// normalizeLongitude maps a longitude in degrees onto (-180, 180].
+func normalizeLongitude(lon float64) float64 {
+	lon = normalizeBearing(lon)
+	if lon > 180 {
+		lon -= 360
+	}
+	return lon
+}
+
+func deg2rad(deg float64) float64 { return deg * math.Pi / 180 }
+
+func rad2deg(rad float64) float64 { return rad * 180 / math.Pi }
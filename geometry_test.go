@@ -0,0 +1,162 @@
+package hfprop
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func near(a, b, eps float64) bool { return math.Abs(a-b) <= eps }
+
+func TestCentralAngle(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want                   float64
+	}{
+		{"same point", 57.7, 11.97, 57.7, 11.97, 0},
+		{"quarter equator", 0, 0, 0, 90, 90},
+		{"half equator", 0, 0, 0, 180, 180},
+		{"pole to pole", 90, 0, -90, 0, 180},
+		{"pole to equator", 90, 0, 0, 45, 90},
+		{"across antimeridian", 0, 170, 0, -170, 20},
+		{"one degree of latitude", 10, 20, 11, 20, 1},
+	}
+	for _, tt := range tests {
+		if got := CentralAngle(tt.lat1, tt.lon1, tt.lat2, tt.lon2); !near(got, tt.want, 1e-9) {
+			t.Errorf("%s: CentralAngle = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTOAChecked(t *testing.T) {
+	const h = 300.0
+	// Distance at which the ray leaves the ground tangentially.
+	horizon := 2 * EarthRadiusKm * math.Acos(EarthRadiusKm/(EarthRadiusKm+h))
+	if !near(horizon, 3834, 1) {
+		t.Fatalf("single-hop horizon at %v km = %v, want about 3834", h, horizon)
+	}
+	tests := []struct {
+		name     string
+		distance float64
+		want     float64
+		eps      float64
+		err      error
+	}{
+		{"zero distance", 0, 90, 0, nil},
+		{"negative distance", -100, 90, 0, nil},
+		{"1000 km", 1000, 28.12, 0.01, nil},
+		{"just inside horizon", horizon - 1, 0.008, 0.008, nil},
+		{"just beyond horizon", horizon + 1, 0, 0, ErrUnreachable},
+		{"far beyond horizon", 30000, 0, 0, ErrUnreachable},
+	}
+	for _, tt := range tests {
+		got, err := TOAChecked(tt.distance, h)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.err)
+		}
+		if !near(got, tt.want, tt.eps) {
+			t.Errorf("%s: TOAChecked = %v, want %v", tt.name, got, tt.want)
+		}
+		if clamped := TOA(tt.distance, h); clamped != got {
+			t.Errorf("%s: TOA = %v, want %v", tt.name, clamped, got)
+		}
+	}
+}
+
+func TestTOAForHeight(t *testing.T) {
+	for _, h := range []float64{110, 200, 300} {
+		for _, d := range []float64{0, 500, 1500, 2500, 5000} {
+			if got, want := TOAForHeight(d, h), TOA(d, h); got != want {
+				t.Errorf("TOAForHeight(%v, %v) = %v, want %v", d, h, got, want)
+			}
+		}
+	}
+}
+
+func TestLongPath(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+	}{
+		{"Gothenburg to New York", 57.7, 11.97, 40.7, -74.0},
+		{"Tokyo to San Francisco", 35.7, 139.7, 37.8, -122.4},
+		{"along the equator", 0, 0, 0, 10},
+	}
+	circumference := 2 * math.Pi * EarthRadiusKm
+	for _, tt := range tests {
+		short := deg2rad(CentralAngle(tt.lat1, tt.lon1, tt.lat2, tt.lon2)) * EarthRadiusKm
+		km, bearing := LongPath(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+		if !near(short+km, circumference, 1e-6) {
+			t.Errorf("%s: short %v + long %v != %v", tt.name, short, km, circumference)
+		}
+		want := normalizeBearing(InitialBearing(tt.lat1, tt.lon1, tt.lat2, tt.lon2) + 180)
+		if !near(bearing, want, 1e-9) {
+			t.Errorf("%s: long-path bearing = %v, want %v", tt.name, bearing, want)
+		}
+	}
+}
+
+func TestInitialBearing(t *testing.T) {
+	tests := []struct {
+		lat2, lon2, want float64
+	}{
+		{10, 0, 0}, {0, 10, 90}, {-10, 0, 180}, {0, -10, 270},
+	}
+	for _, tt := range tests {
+		if got := InitialBearing(0, 0, tt.lat2, tt.lon2); !near(got, tt.want, 1e-9) {
+			t.Errorf("InitialBearing(0, 0, %v, %v) = %v, want %v", tt.lat2, tt.lon2, got, tt.want)
+		}
+	}
+}
+
+func TestAntipode(t *testing.T) {
+	tests := []struct {
+		lat, lon, wantLat, wantLon float64
+	}{
+		{57.7, 11.97, -57.7, -168.03},
+		{0, 0, 0, 180},
+		{-33.9, -151.2, 33.9, 28.8},
+		{90, 0, -90, 180},
+	}
+	for _, tt := range tests {
+		lat, lon := Antipode(tt.lat, tt.lon)
+		if !near(lat, tt.wantLat, 1e-9) || !near(lon, tt.wantLon, 1e-9) {
+			t.Errorf("Antipode(%v, %v) = %v, %v, want %v, %v", tt.lat, tt.lon, lat, lon, tt.wantLat, tt.wantLon)
+		}
+		if a := CentralAngle(tt.lat, tt.lon, lat, lon); !near(a, 180, 1e-6) {
+			t.Errorf("Antipode(%v, %v) is %v degrees away, want 180", tt.lat, tt.lon, a)
+		}
+	}
+}
+
+func TestRadioHorizon(t *testing.T) {
+	tests := []struct {
+		height, want float64
+	}{
+		{-1, 0}, {0, 0}, {1, 4.12}, {10, 13.03}, {30, 22.57}, {100, 41.2},
+	}
+	for _, tt := range tests {
+		if got := RadioHorizon(tt.height); !near(got, tt.want, 0.005*tt.want+1e-9) {
+			t.Errorf("RadioHorizon(%v) = %v, want %v", tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestTOASweep(t *testing.T) {
+	distances := []float64{0, 500, 1000, 3000, 5000}
+	got := TOASweep(300, distances)
+	if len(got) != len(distances) {
+		t.Fatalf("len(TOASweep) = %d, want %d", len(got), len(distances))
+	}
+	for i, d := range distances {
+		if want := TOA(d, 300); got[i] != want {
+			t.Errorf("TOASweep[%d] = %v, want TOA(%v, 300) = %v", i, got[i], d, want)
+		}
+	}
+	for _, empty := range [][]float64{nil, {}} {
+		if got := TOASweep(300, empty); got == nil || len(got) != 0 {
+			t.Errorf("TOASweep(300, %#v) = %#v, want empty slice", empty, got)
+		}
+	}
+}
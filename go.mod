@@ -0,0 +1,3 @@
+module github.com/sa6mwa/hfprop
+
+go 1.21
@@ -0,0 +1,105 @@
+package hfprop
+
+import "math"
+
+// GroundType selects the electrical ground constants used for ground-wave
+// estimates. The zero value is MediumDryGround.
+type GroundType int
+
+// Ground types with their ITU-R P.527 constants.
+const (
+	MediumDryGround GroundType = iota // σ = 1e-3 S/m, εr = 15
+	SeaWater                          // σ = 5 S/m, εr = 70
+	FreshWater                        // σ = 3e-3 S/m, εr = 80
+	WetGround                         // σ = 1e-2 S/m, εr = 30
+	VeryDryGround                     // σ = 1e-4 S/m, εr = 3
+)
+
+// Conductivity returns the ground conductivity in S/m.
+func (g GroundType) Conductivity() float64 {
+	switch g {
+	case SeaWater:
+		return 5
+	case FreshWater:
+		return 3e-3
+	case WetGround:
+		return 1e-2
+	case VeryDryGround:
+		return 1e-4
+	}
+	return 1e-3
+}
+
+// RelativePermittivity returns the relative permittivity of the ground.
+func (g GroundType) RelativePermittivity() float64 {
+	switch g {
+	case SeaWater:
+		return 70
+	case FreshWater:
+		return 80
+	case WetGround:
+		return 30
+	case VeryDryGround:
+		return 3
+	}
+	return 15
+}
+
+const (
+	// groundWaveThreshold is the field strength in µV/m (20 dBµV/m) taken as
+	// the edge of ground-wave service, typical of a quiet rural HF noise
+	// floor.
+	groundWaveThreshold = 10
+	// groundWaveRefField is the unattenuated field in µV/m at 1 km from a
+	// short vertical monopole radiating 1 kW.
+	groundWaveRefField = 3e5
+)
+
+// GroundWaveRange returns the estimated ground-wave service range in km for
+// a vertically polarized transmitter of txPowerW at frequencyMHz over the
+// given ground. Together with the skip distance it brackets the zone with no
+// reliable coverage.
+//
+// Within the flat-Earth limit of 80/∛f km the model is Norton's surface wave.
+// The field is the 1 kW reference field scaled by √(P/1 kW) and by 1/d, times
+// the attenuation factor A(p) ≈ (2 + 0.3p)/(2 + p + 0.6p²) of the numerical
+// distance p. Beyond that limit the wave diffracts around the curved Earth
+// and an extra loss of 17.6·X dB is applied, the leading term of the ITU-R
+// P.526 smooth-Earth distance loss, with X = 2.188·∛f·ae^(-2/3)·Δd for the
+// distance Δd past the limit and the 4/3 effective Earth radius ae. The range
+// is where the field falls to groundWaveThreshold, capped at half the Earth's
+// circumference. Higher frequencies and poorer ground shorten the range.
+func GroundWaveRange(frequencyMHz, txPowerW float64, ground GroundType) (km float64) {
+	if frequencyMHz <= 0 || txPowerW <= 0 {
+		return 0
+	}
+	lambdaKm := speedOfLight / (frequencyMHz * 1e6) / 1000
+	x := 1.8e4 * ground.Conductivity() / frequencyMHz
+	b := math.Atan((ground.RelativePermittivity() + 1) / x)
+	flatKm := 80 / math.Cbrt(frequencyMHz)
+	// Diffraction loss in dB per km beyond flatKm.
+	ae := 4.0 / 3 * EarthRadiusKm
+	lossPerKm := 17.6 * 2.188 * math.Cbrt(frequencyMHz) * math.Pow(ae, -2.0/3)
+	field := func(d float64) float64 {
+		p := math.Pi * d / lambdaKm * math.Cos(b) / x
+		a := (2 + 0.3*p) / (2 + p + 0.6*p*p)
+		e := groundWaveRefField * math.Sqrt(txPowerW/1000) * a / d
+		if d > flatKm {
+			e *= math.Pow(10, -lossPerKm*(d-flatKm)/20)
+		}
+		return e
+	}
+	lo, hi := 0.0, math.Pi*EarthRadiusKm
+	if field(hi) >= groundWaveThreshold {
+		return hi
+	}
+	for i := 0; i < 100 && hi-lo > 1e-3; i++ {
+		mid := (lo + hi) / 2
+		if field(mid) >= groundWaveThreshold {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
@@ -0,0 +1,32 @@
+package hfprop
+
+import "testing"
+
+func TestGroundWaveRange(t *testing.T) {
+	grounds := []GroundType{SeaWater, FreshWater, WetGround, MediumDryGround, VeryDryGround}
+	for _, g := range grounds {
+		prev := 2 * EarthRadiusKm
+		for _, f := range []float64{1.8, 3.5, 7, 14, 28} {
+			r := GroundWaveRange(f, 100, g)
+			if r <= 0 || r >= prev {
+				t.Errorf("ground %d, %v MHz: range %v km, want below %v km", g, f, r, prev)
+			}
+			prev = r
+		}
+	}
+	// 100 W on 160 m over sea water carries a few hundred km, not thousands.
+	if r := GroundWaveRange(1.8, 100, SeaWater); r < 150 || r > 1000 {
+		t.Errorf("1.8 MHz, 100 W over sea water: %v km, want 150-1000 km", r)
+	}
+	if sea, dry := GroundWaveRange(3.5, 100, SeaWater), GroundWaveRange(3.5, 100, VeryDryGround); sea <= dry {
+		t.Errorf("3.5 MHz: sea water %v km should beat very dry ground %v km", sea, dry)
+	}
+	if low, high := GroundWaveRange(7, 10, WetGround), GroundWaveRange(7, 1000, WetGround); low >= high {
+		t.Errorf("7 MHz: 10 W reaches %v km, 1 kW %v km", low, high)
+	}
+	for _, tt := range []struct{ f, p float64 }{{0, 100}, {7, 0}, {-1, -1}} {
+		if r := GroundWaveRange(tt.f, tt.p, SeaWater); r != 0 {
+			t.Errorf("GroundWaveRange(%v, %v) = %v, want 0", tt.f, tt.p, r)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package hfprop
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrLayerPenetrated is returned by SelectReflectingLayer when a frequency
+// passes through every layer.
+var ErrLayerPenetrated = errors.New("frequency penetrates every layer")
+
+// LayerRatios holds the ratios between the critical frequencies of the
+// ionospheric layers observed in one sounding. A ratio is NaN when either of
+// its frequencies is missing.
+type LayerRatios struct {
+	F2ToE  float64 // foF2/foE
+	F2ToF1 float64 // foF2/foF1
+}
+
+// CriticalFrequencyRatios returns the foF2/foE and foF2/foF1 ratios for the
+// critical frequencies (MHz) of one sounding. It also returns a note for
+// each physically implausible combination, such as a lower layer with a
+// critical frequency at or above that of a higher layer. Notes are nil when
+// the set is consistent. Non-positive values mark a missing layer, which is
+// normal for F1 at night, and are not flagged.
+func CriticalFrequencyRatios(foF2, foF1, foE float64) (LayerRatios, []string) {
+	ratio := func(a, b float64) float64 {
+		if a <= 0 || b <= 0 {
+			return math.NaN()
+		}
+		return a / b
+	}
+	r := LayerRatios{F2ToE: ratio(foF2, foE), F2ToF1: ratio(foF2, foF1)}
+	var notes []string
+	if r.F2ToE <= 1 {
+		notes = append(notes, "foE is at or above foF2")
+	}
+	if r.F2ToF1 <= 1 {
+		notes = append(notes, "foF1 is at or above foF2")
+	}
+	if ratio(foF1, foE) <= 1 {
+		notes = append(notes, "foE is at or above foF1")
+	}
+	return r, notes
+}
+
+// SelectReflectingLayer returns the layer, "E" or "F2", that reflects
+// frequencyMHz at vertical incidence given the critical frequencies foE and
+// foF2, and the height in km to use for that layer's geometry:
+// ELayerHeightKm for E and F2LayerHeightKm for F2. Substitute a measured hmF2
+// for the F2 height when one is available. A non-positive foE means there is
+// no E layer, as at night. ErrLayerPenetrated is returned when the frequency
+// exceeds foF2. For oblique paths, compare against ScreeningFrequency
+// instead of foE.
+func SelectReflectingLayer(frequencyMHz, foE, foF2 float64) (layer string, reflectionHeightKm float64, err error) {
+	switch {
+	case foE > 0 && frequencyMHz <= foE:
+		return "E", ELayerHeightKm, nil
+	case foF2 > 0 && frequencyMHz <= foF2:
+		return "F2", F2LayerHeightKm, nil
+	}
+	return "", 0, ErrLayerPenetrated
+}
@@ -0,0 +1,61 @@
+package hfprop
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCriticalFrequencyRatios(t *testing.T) {
+	r, notes := CriticalFrequencyRatios(9, 5, 3)
+	if notes != nil {
+		t.Errorf("consistent set: notes %q, want nil", notes)
+	}
+	if r.F2ToE != 3 || r.F2ToF1 != 1.8 {
+		t.Errorf("consistent set: ratios %+v, want 3 and 1.8", r)
+	}
+
+	r, notes = CriticalFrequencyRatios(3, 0, 4)
+	if len(notes) != 1 || !strings.Contains(notes[0], "foE is at or above foF2") {
+		t.Errorf("foE above foF2: notes %q, want one about foE and foF2", notes)
+	}
+	if r.F2ToE != 0.75 || !math.IsNaN(r.F2ToF1) {
+		t.Errorf("foE above foF2: ratios %+v, want 0.75 and NaN", r)
+	}
+
+	if _, notes := CriticalFrequencyRatios(4, 5, 6); len(notes) != 3 {
+		t.Errorf("inverted layers: notes %q, want 3", notes)
+	}
+	if r, notes := CriticalFrequencyRatios(5, 0, 0); notes != nil || !math.IsNaN(r.F2ToE) {
+		t.Errorf("night-time F2 only: %+v, %q", r, notes)
+	}
+}
+
+func TestSelectReflectingLayer(t *testing.T) {
+	const foE, foF2 = 3.0, 8.0
+	tests := []struct {
+		name      string
+		frequency float64
+		foE       float64
+		layer     string
+		height    float64
+		err       error
+	}{
+		{"160 m below foE", 1.8, foE, "E", ELayerHeightKm, nil},
+		{"at foE", 3, foE, "E", ELayerHeightKm, nil},
+		{"80 m above foE", 3.5, foE, "F2", F2LayerHeightKm, nil},
+		{"no E layer", 1.8, 0, "F2", F2LayerHeightKm, nil},
+		{"at foF2", 8, foE, "F2", F2LayerHeightKm, nil},
+		{"30 m above foF2", 10.1, foE, "", 0, ErrLayerPenetrated},
+	}
+	for _, tt := range tests {
+		layer, height, err := SelectReflectingLayer(tt.frequency, tt.foE, foF2)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.err)
+		}
+		if layer != tt.layer || height != tt.height {
+			t.Errorf("%s: %q at %v km, want %q at %v km", tt.name, layer, height, tt.layer, tt.height)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package hfprop
+
+import "math"
+
+// Model constants used by the geometry and MUF helpers. They are variables so
+// that higher-accuracy work can override them. Set them before use: they are
+// read without synchronization.
+var (
+	// EarthRadiusKm is the radius in km of the spherical Earth model. The
+	// default is derived from a 40000 km circumference.
+	EarthRadiusKm = 40000 / 2 / math.Pi
+	// ELayerHeightKm is the nominal virtual height in km of the E layer.
+	ELayerHeightKm = 110.0
+	// F1LayerHeightKm is the nominal reflection height in km of the F1
+	// layer.
+	F1LayerHeightKm = 200.0
+	// F2LayerHeightKm is the nominal height in km of the F2 layer peak, used
+	// when no measured hmF2 is at hand.
+	F2LayerHeightKm = 300.0
+)
+
+// DefaultMinTOADegrees is the lowest usable take-off angle in degrees
+// assumed by helpers that take no angle floor. Their ForAntenna variants
+// accept the floor for a specific antenna, as estimated by MinTakeoffAngle.
+const DefaultMinTOADegrees = 3.0
@@ -0,0 +1,24 @@
+package hfprop
+
+import "testing"
+
+func TestEarthRadiusOverride(t *testing.T) {
+	a, b := NewStation("a", 0, 0), NewStation("b", 0, 90)
+	distance, toa := GreatCircleDistance(a, b), TOA(1000, 300)
+
+	saved := EarthRadiusKm
+	t.Cleanup(func() { EarthRadiusKm = saved })
+	EarthRadiusKm = 2 * saved
+
+	if got := GreatCircleDistance(a, b); !near(got, 2*distance, 1e-6) {
+		t.Errorf("GreatCircleDistance with doubled radius = %v, want %v", got, 2*distance)
+	}
+	if got := TOA(1000, 300); near(got, toa, 1e-3) {
+		t.Errorf("TOA did not change with the radius: %v", got)
+	}
+	// Scaling every length by the same factor keeps the geometry, and with it
+	// the angle, identical.
+	if got := TOA(2000, 600); !near(got, toa, 1e-9) {
+		t.Errorf("TOA(2000, 600) with doubled radius = %v, want %v", got, toa)
+	}
+}
@@ -0,0 +1,132 @@
+package hfprop
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// mufdDistanceKm is the ground distance in km of the DIDB MUFD and MD
+	// characteristics unless another DMUF is requested.
+	mufdDistanceKm = 3000
+	// mFactorTolerance is the relative deviation between the reported and
+	// the geometric M-factor that ConsistencyCheck accepts.
+	mFactorTolerance = 0.15
+)
+
+// VerticalMUF returns the maximum usable frequency in MHz for vertical
+// incidence (NVIS and overhead paths) at a station, which is foF2 itself. It
+// exists so that zero-distance work can use the same MUF-shaped API as the
+// oblique helpers instead of special-casing foF2.
+func VerticalMUF(foF2 float64) float64 {
+	return foF2
+}
+
+// MUFMargin returns the ratio of an operating frequency to the MUF, both in
+// MHz, and whether the frequency is above the MUF. A ratio below 1 is
+// headroom, and the closer it is to 1 the more fragile the path. A frequency
+// exactly at the MUF is not above it. A non-positive MUF gives an infinite
+// ratio and reports any positive frequency as above it.
+func MUFMargin(frequencyMHz float64, muf float64) (ratio float64, aboveMUF bool) {
+	if muf <= 0 {
+		return math.Inf(1), frequencyMHz > 0
+	}
+	return frequencyMHz / muf, frequencyMHz > muf
+}
+
+// F1MUF returns the maximum usable frequency in MHz for a single hop of
+// distanceKm reflecting off the F1 layer at F1LayerHeightKm, given its
+// critical frequency foF1. The lower reflection height gives a larger
+// obliquity factor than the F2 layer over the same distance, which is how
+// F1 can occasionally support a higher MUF at midday. It returns 0 for paths
+// beyond the single-hop F1 range (about 3150 km), which no single F1 hop
+// supports.
+func F1MUF(foF1, distanceKm float64) float64 {
+	toa, err := TOAChecked(distanceKm, F1LayerHeightKm)
+	if err != nil {
+		return 0
+	}
+	return foF1 * secantFactor(toa, F1LayerHeightKm)
+}
+
+// ScreeningFrequency returns the E-layer screening frequency in MHz for a ray
+// leaving the ground at toaDegrees: the highest frequency the E layer, with
+// critical frequency foE, still reflects at that angle. Lower frequencies are
+// turned back by the E layer and never reach the F layer, so an F-layer path
+// at that elevation is blocked below this frequency.
+func ScreeningFrequency(foE, toaDegrees float64) float64 {
+	return foE * secantFactor(toaDegrees, ELayerHeightKm)
+}
+
+// OptimalTOA returns the take-off angle in degrees, and the maximum usable
+// frequency in MHz it yields, that maximize the usable frequency on a path of
+// distanceKm with an F2 layer of critical frequency foF2 at hmF2, for an
+// antenna whose lowest usable take-off angle is DefaultMinTOADegrees. See
+// OptimalTOAForAntenna.
+func OptimalTOA(distanceKm, hmF2, foF2 float64) (toaDegrees, maxUsableFreq float64) {
+	return OptimalTOAForAntenna(distanceKm, hmF2, foF2, DefaultMinTOADegrees)
+}
+
+// OptimalTOAForAntenna is like OptimalTOA for an antenna whose lowest usable
+// take-off angle is minTOADegrees (see MinTakeoffAngle).
+//
+// Hop counts from one upwards are swept, and each hop covers an equal share
+// of the distance. Fewer hops mean lower angles and a larger secant factor,
+// so the sweep stops at the first hop count whose take-off angle is at least
+// minTOADegrees. The returned MUF is therefore never below that of any
+// single hop the antenna can launch. A zero or negative distance is treated
+// as vertical incidence. Zeroes are returned if hmF2 is not positive,
+// distanceKm is not finite or minTOADegrees leaves no usable hop.
+func OptimalTOAForAntenna(distanceKm, hmF2, foF2, minTOADegrees float64) (toaDegrees, maxUsableFreq float64) {
+	if distanceKm <= 0 {
+		return 90, VerticalMUF(foF2)
+	}
+	if hmF2 <= 0 || math.IsNaN(distanceKm) || math.IsInf(distanceKm, 0) {
+		return 0, 0
+	}
+	hopRange := MaxSingleHopRange(hmF2, minTOADegrees)
+	if hopRange <= 0 {
+		return 0, 0
+	}
+	maxHops := int(math.Ceil(distanceKm/hopRange)) + 1
+	for hops := 1; hops <= maxHops; hops++ {
+		toa, err := TOAChecked(distanceKm/float64(hops), hmF2)
+		if err != nil || toa < minTOADegrees {
+			continue
+		}
+		return toa, foF2 * secantFactor(toa, hmF2)
+	}
+	return 0, 0
+}
+
+// ConsistencyCheck reports whether foF2 and MUFD (both MHz, MUFD for the
+// default 3000 km distance) and hmF2 (km) from one sounding are internally
+// consistent. The reported M-factor MUFD/foF2 is compared with the factor
+// implied by reflection at hmF2 over 3000 km. Deviations beyond 15%, an MUFD
+// below foF2 and missing (non-positive) values are returned as notes. The
+// geometric factor ignores group retardation below the peak, so it is only a
+// coarse cross-check that catches gross autoscaling errors.
+func ConsistencyCheck(foF2, mufd, hmF2 float64) (ok bool, notes []string) {
+	if foF2 <= 0 || mufd <= 0 || hmF2 <= 0 {
+		return false, []string{fmt.Sprintf("missing value (foF2=%g, MUFD=%g, hmF2=%g)", foF2, mufd, hmF2)}
+	}
+	reported := mufd / foF2
+	if reported < 1 {
+		notes = append(notes, fmt.Sprintf("MUFD %g MHz is below foF2 %g MHz", mufd, foF2))
+	}
+	expected := secantFactor(TOA(mufdDistanceKm, hmF2), hmF2)
+	if dev := reported/expected - 1; math.Abs(dev) > mFactorTolerance {
+		notes = append(notes, fmt.Sprintf("M(3000)F2 %.2f deviates %+.0f%% from %.2f expected for hmF2 %g km",
+			reported, dev*100, expected, hmF2))
+	}
+	return notes == nil, notes
+}
+
+// secantFactor returns the obliquity factor sec φ, where φ is the angle of
+// incidence at a layer heightKm above a spherical Earth for a ray leaving the
+// ground at toaDegrees. Multiplying a critical frequency by the factor gives
+// the highest frequency the layer reflects at that elevation.
+func secantFactor(toaDegrees, heightKm float64) float64 {
+	sinPhi := EarthRadiusKm * math.Cos(deg2rad(toaDegrees)) / (EarthRadiusKm + heightKm)
+	return 1 / math.Sqrt(1-sinPhi*sinPhi)
+}
@@ -0,0 +1,178 @@
+package hfprop
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestVerticalMUF(t *testing.T) {
+	for _, foF2 := range []float64{0, 2.5, 7, 14.2} {
+		if got := VerticalMUF(foF2); got != foF2 {
+			t.Errorf("VerticalMUF(%v) = %v, want foF2", foF2, got)
+		}
+	}
+}
+
+func TestScreeningFrequency(t *testing.T) {
+	if got := ScreeningFrequency(3.5, 90); !near(got, 3.5, 1e-9) {
+		t.Errorf("vertical incidence: %v, want foE", got)
+	}
+	const toa = 10.0
+	quiet, strong := ScreeningFrequency(1.5, toa), ScreeningFrequency(4, toa)
+	if quiet >= strong {
+		t.Errorf("foE 4 MHz screens up to %v MHz, below foE 1.5 MHz at %v MHz", strong, quiet)
+	}
+	// A 7 MHz ray at 10 degrees passes a weak E layer but is turned back by
+	// a strong one, while 21 MHz gets through both.
+	if quiet >= 7 || strong <= 7 || strong >= 21 {
+		t.Errorf("screening frequencies %v and %v MHz do not bracket 7 MHz", quiet, strong)
+	}
+	if low, high := ScreeningFrequency(4, 5), ScreeningFrequency(4, 30); low <= high {
+		t.Errorf("lower angles should be screened to higher frequencies: %v <= %v", low, high)
+	}
+}
+
+func TestOptimalTOA(t *testing.T) {
+	const hmF2, foF2 = 300.0, 7.0
+	// Paths a single hop covers at or above the floor: the sweep must keep
+	// that hop, so the MUF is the single-hop MUF.
+	for _, d := range []float64{500, 1000, 2000, 3000} {
+		toa, muf := OptimalTOA(d, hmF2, foF2)
+		if want := TOA(d, hmF2); toa != want {
+			t.Errorf("OptimalTOA(%v): toa = %v, want single hop %v", d, toa, want)
+		}
+		if single := foF2 * secantFactor(TOA(d, hmF2), hmF2); muf < single {
+			t.Errorf("OptimalTOA(%v): MUF %v below single-hop MUF %v", d, muf, single)
+		}
+	}
+	tests := []struct {
+		name     string
+		distance float64
+		minTOA   float64
+		wantTOA  float64
+	}{
+		// One hop of 3800 km leaves at about 0.15 degrees.
+		{"grazing hop rejected", 3800, DefaultMinTOADegrees, TOA(1900, hmF2)},
+		{"grazing hop allowed", 3800, 0, TOA(3800, hmF2)},
+		{"three hops", 9000, DefaultMinTOADegrees, TOA(3000, hmF2)},
+		{"high floor forces more hops", 2000, 20, TOA(1000, hmF2)},
+	}
+	for _, tt := range tests {
+		toa, muf := OptimalTOAForAntenna(tt.distance, hmF2, foF2, tt.minTOA)
+		if toa < tt.minTOA {
+			t.Errorf("%s: toa %v below floor %v", tt.name, toa, tt.minTOA)
+		}
+		if !near(toa, tt.wantTOA, 1e-9) {
+			t.Errorf("%s: toa = %v, want %v", tt.name, toa, tt.wantTOA)
+		}
+		if want := foF2 * secantFactor(toa, hmF2); muf != want {
+			t.Errorf("%s: MUF = %v, want %v", tt.name, muf, want)
+		}
+	}
+	if toa, muf := OptimalTOA(-10, hmF2, foF2); toa != 90 || muf != foF2 {
+		t.Errorf("vertical incidence: %v, %v", toa, muf)
+	}
+	for _, d := range []float64{math.NaN(), math.Inf(1)} {
+		if toa, muf := OptimalTOA(d, hmF2, foF2); toa != 0 || muf != 0 {
+			t.Errorf("OptimalTOA(%v) = %v, %v, want zeroes", d, toa, muf)
+		}
+	}
+	if toa, muf := OptimalTOA(1000, 0, foF2); toa != 0 || muf != 0 {
+		t.Errorf("zero hmF2: %v, %v, want zeroes", toa, muf)
+	}
+	if toa, muf := OptimalTOAForAntenna(1000, hmF2, foF2, 90); toa != 0 || muf != 0 {
+		t.Errorf("90 degree floor: %v, %v, want zeroes", toa, muf)
+	}
+}
+
+func TestMUFMargin(t *testing.T) {
+	tests := []struct {
+		name           string
+		frequency, muf float64
+		ratio          float64
+		above          bool
+	}{
+		{"at the MUF", 14, 14, 1, false},
+		{"just above the MUF", 14.001, 14, 14.001 / 14, true},
+		{"just below the MUF", 13.999, 14, 13.999 / 14, false},
+		{"half the MUF", 7, 14, 0.5, false},
+		{"no MUF", 7, 0, math.Inf(1), true},
+		{"no MUF, no frequency", 0, 0, math.Inf(1), false},
+	}
+	for _, tt := range tests {
+		ratio, above := MUFMargin(tt.frequency, tt.muf)
+		if (ratio != tt.ratio && !near(ratio, tt.ratio, 1e-12)) || above != tt.above {
+			t.Errorf("%s: MUFMargin(%v, %v) = %v, %v, want %v, %v",
+				tt.name, tt.frequency, tt.muf, ratio, above, tt.ratio, tt.above)
+		}
+	}
+}
+
+func TestF1MUF(t *testing.T) {
+	for _, d := range []float64{500, 1000, 1500, 2000} {
+		f1 := F1MUF(1, d)
+		if want := secantFactor(TOA(d, F1LayerHeightKm), F1LayerHeightKm); !near(f1, want, 1e-12) {
+			t.Errorf("F1MUF(1, %v) = %v, want factor at %v km %v", d, f1, F1LayerHeightKm, want)
+		}
+		// The lower F1 layer is met more obliquely over the same distance.
+		if f2 := secantFactor(TOA(d, F2LayerHeightKm), F2LayerHeightKm); f1 <= f2 {
+			t.Errorf("%v km: F1 factor %v not above F2 factor %v", d, f1, f2)
+		}
+	}
+	if got := F1MUF(5, 0); got != 5 {
+		t.Errorf("F1MUF(5, 0) = %v, want foF1", got)
+	}
+	// At midday, foF1 5 MHz can beat foF2 6 MHz over 1500 km.
+	if f1, f2 := F1MUF(5, 1500), 6*secantFactor(TOA(1500, F2LayerHeightKm), F2LayerHeightKm); f1 <= f2 {
+		t.Errorf("1500 km: F1 MUF %v not above F2 MUF %v", f1, f2)
+	}
+}
+
+func TestConsistencyCheck(t *testing.T) {
+	const foF2, hmF2 = 7.0, 300.0
+	mufd := foF2 * secantFactor(TOA(mufdDistanceKm, hmF2), hmF2)
+	tests := []struct {
+		name             string
+		foF2, mufd, hmF2 float64
+		ok               bool
+		notes            int
+		note             string
+	}{
+		{"consistent", foF2, mufd, hmF2, true, 0, ""},
+		{"within tolerance", foF2, 1.1 * mufd, hmF2, true, 0, ""},
+		{"M-factor too low", foF2, 0.7 * mufd, hmF2, false, 1, "deviates"},
+		{"hmF2 far too high", foF2, mufd, 700, false, 1, "deviates"},
+		{"MUFD below foF2", foF2, 5, hmF2, false, 2, "below foF2"},
+		{"missing hmF2", foF2, mufd, 0, false, 1, "missing"},
+		{"missing foF2", -1, mufd, hmF2, false, 1, "missing"},
+	}
+	for _, tt := range tests {
+		ok, notes := ConsistencyCheck(tt.foF2, tt.mufd, tt.hmF2)
+		if ok != tt.ok || len(notes) != tt.notes {
+			t.Errorf("%s: ok = %v with notes %q, want %v with %d", tt.name, ok, notes, tt.ok, tt.notes)
+			continue
+		}
+		if tt.note != "" && !strings.Contains(notes[0], tt.note) {
+			t.Errorf("%s: note %q does not mention %q", tt.name, notes[0], tt.note)
+		}
+	}
+}
+
+func TestF1MUFBeyondHorizon(t *testing.T) {
+	horizon := singleHopHorizon(F1LayerHeightKm)
+	if got := F1MUF(5, horizon-10); got <= 5 {
+		t.Errorf("F1MUF just inside the F1 horizon = %v, want a grazing-hop MUF", got)
+	}
+	for _, d := range []float64{horizon + 10, 5000, 10000} {
+		if got := F1MUF(5, d); got != 0 {
+			t.Errorf("F1MUF(5, %v) = %v, want 0 beyond the F1 horizon", d, got)
+		}
+	}
+	// Past the F1 horizon but within a single F2 hop, only F2 carries the path.
+	const d = 3500.0
+	f2 := 6 * secantFactor(TOA(d, F2LayerHeightKm), F2LayerHeightKm)
+	if f1 := F1MUF(5, d); f1 >= f2 {
+		t.Errorf("%v km: F1 MUF %v should not beat F2 MUF %v", d, f1, f2)
+	}
+}
@@ -0,0 +1,97 @@
+package hfprop
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	// ErrPolarDay is returned by SunriseSunset when the Sun stays above the
+	// horizon all day.
+	ErrPolarDay = errors.New("sun does not set on this day (polar day)")
+	// ErrPolarNight is returned by SunriseSunset when the Sun stays below the
+	// horizon all day.
+	ErrPolarNight = errors.New("sun does not rise on this day (polar night)")
+)
+
+// sunriseZenith is the solar zenith angle in degrees at sunrise and sunset,
+// allowing for atmospheric refraction and the solar disc radius.
+const sunriseZenith = 90.833
+
+// SunriseSunset returns the UTC times of sunrise and sunset at lat, lon
+// (decimal degrees, east positive) for the local solar day of date: the
+// sunrise before and the sunset after local mean noon at lon on the UTC
+// calendar day of date. East of Greenwich the sunrise may therefore fall on
+// the previous UTC day, and west of it the sunset on the next, as they do on
+// a local clock near the date line. It uses the NOAA solar calculator
+// equations (after Meeus), evaluated at each event after a first estimate at
+// local noon, which are good to about a minute outside the polar regions.
+// ErrPolarDay or ErrPolarNight is returned, with zero times, when the Sun
+// does not cross the horizon that day.
+func SunriseSunset(lat, lon float64, date time.Time) (rise, set time.Time, err error) {
+	lon = normalizeLongitude(lon)
+	date = date.UTC()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	noon := day.Add(time.Duration((720 - 4*lon) * float64(time.Minute)))
+	rise, set = noon, noon
+	for i := 0; i < 2; i++ {
+		if rise, err = horizonCrossing(lat, lon, day, rise, -1); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if set, err = horizonCrossing(lat, lon, day, set, 1); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	return rise, set, nil
+}
+
+// horizonCrossing returns the time at which the Sun crosses the horizon at
+// lat, lon, within the local solar day around local mean noon on the UTC
+// calendar day starting at day, using the solar position at estimate. A sign
+// of -1 selects sunrise and +1 sunset.
+func horizonCrossing(lat, lon float64, day, estimate time.Time, sign float64) (time.Time, error) {
+	decl, eqTime := solarPosition(estimate)
+	phi := deg2rad(lat)
+	cosHA := math.Cos(deg2rad(sunriseZenith))/(math.Cos(phi)*math.Cos(decl)) - math.Tan(phi)*math.Tan(decl)
+	switch {
+	case cosHA > 1:
+		return time.Time{}, ErrPolarNight
+	case cosHA < -1:
+		return time.Time{}, ErrPolarDay
+	}
+	ha := rad2deg(math.Acos(cosHA))
+	minutes := 720 - 4*(lon-sign*ha) - eqTime
+	return day.Add(time.Duration(minutes * float64(time.Minute))), nil
+}
+
+// solarPosition returns the solar declination in radians and the equation
+// of time in minutes at t, using the NOAA solar calculator equations.
+func solarPosition(t time.Time) (decl, eqTime float64) {
+	// Julian centuries since J2000.0.
+	jd := float64(t.UTC().UnixNano())/float64(24*time.Hour) + 2440587.5
+	jc := (jd - 2451545) / 36525
+	l0 := deg2rad(math.Mod(280.46646+jc*(36000.76983+jc*0.0003032), 360))
+	m := deg2rad(357.52911 + jc*(35999.05029-0.0001537*jc))
+	e := 0.016708634 - jc*(0.000042037+0.0000001267*jc)
+	c := math.Sin(m)*(1.914602-jc*(0.004817+0.000014*jc)) +
+		math.Sin(2*m)*(0.019993-0.000101*jc) + math.Sin(3*m)*0.000289
+	omega := deg2rad(125.04 - 1934.136*jc)
+	lambda := deg2rad(rad2deg(l0) + c - 0.00569 - 0.00478*math.Sin(omega))
+	meanObliq := 23 + (26+(21.448-jc*(46.815+jc*(0.00059-jc*0.001813)))/60)/60
+	obliq := deg2rad(meanObliq + 0.00256*math.Cos(omega))
+	decl = math.Asin(math.Sin(obliq) * math.Sin(lambda))
+	y := math.Tan(obliq/2) * math.Tan(obliq/2)
+	eqTime = 4 * rad2deg(y*math.Sin(2*l0)-2*e*math.Sin(m)+4*e*y*math.Sin(m)*math.Cos(2*l0)-
+		0.5*y*y*math.Sin(4*l0)-1.25*e*e*math.Sin(2*m))
+	return decl, eqTime
+}
+
+// LocalMeanTime returns t in the local mean solar time of longitude lon
+// (decimal degrees, east positive): UTC offset by 4 minutes per degree, i.e.
+// one hour per 15°. The instant is unchanged. Only the wall clock, in a fixed
+// zone named "LMT" whose offset is rounded to the second, differs.
+func LocalMeanTime(lon float64, t time.Time) time.Time {
+	offset := int(math.Round(normalizeLongitude(lon) * 240))
+	return t.In(time.FixedZone("LMT", offset))
+}
@@ -0,0 +1,90 @@
+package hfprop
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSunriseSunset(t *testing.T) {
+	solstice := time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC)
+	utc := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 6, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name      string
+		lat, lon  float64
+		rise, set time.Time
+	}{
+		{"Greenwich", 51.4769, 0, utc(21, 3, 43), utc(21, 20, 21)},
+		// West of Greenwich the evening sunset is on the next UTC day.
+		{"New York", 40.7128, -74.006, utc(21, 9, 25), utc(22, 0, 31)},
+		{"San Francisco", 37.7749, -122.4194, utc(21, 12, 48), utc(22, 3, 35)},
+		// East of Greenwich the morning sunrise is on the previous UTC day.
+		{"Tokyo", 35.6762, 139.6503, utc(20, 19, 25), utc(21, 10, 0)},
+	}
+	for _, tt := range tests {
+		// The time of day of date does not matter, only its UTC calendar day.
+		for _, date := range []time.Time{solstice, solstice.Add(23 * time.Hour)} {
+			rise, set, err := SunriseSunset(tt.lat, tt.lon, date)
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+				continue
+			}
+			if d := rise.Sub(tt.rise).Abs(); d > 2*time.Minute {
+				t.Errorf("%s: sunrise %v, want %v", tt.name, rise, tt.rise)
+			}
+			if d := set.Sub(tt.set).Abs(); d > 2*time.Minute {
+				t.Errorf("%s: sunset %v, want %v", tt.name, set, tt.set)
+			}
+		}
+	}
+}
+
+func TestSunriseSunsetPolar(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		date     time.Time
+		err      error
+	}{
+		{"Tromsø midsummer", 69.65, 18.96, time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC), ErrPolarDay},
+		{"Tromsø midwinter", 69.65, 18.96, time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC), ErrPolarNight},
+		{"McMurdo midwinter", -77.85, 166.67, time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC), ErrPolarNight},
+	}
+	for _, tt := range tests {
+		rise, set, err := SunriseSunset(tt.lat, tt.lon, tt.date)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.err)
+		}
+		if !rise.IsZero() || !set.IsZero() {
+			t.Errorf("%s: times %v, %v, want zero", tt.name, rise, set)
+		}
+	}
+}
+
+func TestLocalMeanTime(t *testing.T) {
+	utc := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lon          float64
+		hour, minute int
+	}{
+		{0, 12, 0},
+		{15, 13, 0},
+		{-97.5, 5, 30},
+		{180, 0, 0},
+		{190, 0, 40},
+	}
+	for _, tt := range tests {
+		lmt := LocalMeanTime(tt.lon, utc)
+		if !lmt.Equal(utc) {
+			t.Errorf("LocalMeanTime(%v) is %v, a different instant", tt.lon, lmt)
+		}
+		if lmt.Hour() != tt.hour || lmt.Minute() != tt.minute {
+			t.Errorf("LocalMeanTime(%v) = %v, want %02d:%02d", tt.lon, lmt.Format("15:04"), tt.hour, tt.minute)
+		}
+	}
+	if _, offset := LocalMeanTime(15, utc).Zone(); offset != 3600 {
+		t.Errorf("15°E offset = %d s, want one hour", offset)
+	}
+}
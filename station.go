@@ -0,0 +1,134 @@
+package hfprop
+
+import "math"
+
+// Station is a named location on the Earth. Ionosondes in the GIRO network
+// carry their URSI code. Pseudo-stations created with NewStation for
+// arbitrary points, such as a home QTH, do not.
+type Station struct {
+	Name      string
+	URSICode  string
+	Latitude  float64
+	Longitude float64
+}
+
+// NewStation returns a pseudo-station at lat, lon (decimal degrees) with an
+// empty URSI code. It works with every geometry function, but IsPseudo
+// reports it as unusable for fetching ionosonde data.
+func NewStation(name string, lat, lon float64) Station {
+	return Station{Name: name, Latitude: lat, Longitude: lon}
+}
+
+// IsPseudo reports whether s lacks an URSI code and therefore cannot be used
+// to fetch ionosonde data.
+func (s Station) IsPseudo() bool {
+	return s.URSICode == ""
+}
+
+// GreatCircleDistance returns the great-circle distance in km between two
+// stations over the spherical Earth model.
+func GreatCircleDistance(from, to Station) float64 {
+	return deg2rad(CentralAngle(from.Latitude, from.Longitude, to.Latitude, to.Longitude)) * EarthRadiusKm
+}
+
+// MagneticBearing returns the initial great-circle bearing from one station
+// to another relative to magnetic north, in degrees [0, 360). The
+// declination is the local magnetic declination at from in degrees, positive
+// east. The magnetic bearing is the true bearing minus the declination.
+func MagneticBearing(from Station, to Station, declination float64) float64 {
+	return normalizeBearing(InitialBearing(from.Latitude, from.Longitude, to.Latitude, to.Longitude) - declination)
+}
+
+// TiltCorrectedBearing returns the initial bearing in degrees [0, 360) from
+// one station to another, corrected to first order for an ionospheric tilt
+// across the path. The tiltGradient is the cross-path slope of the
+// reflecting layer (km of height per km of horizontal distance), positive
+// when the layer rises to the right of the direction of travel.
+//
+// A mirror with slope g at height h displaces the reflection point of the
+// hop by −g·h to the side. The returned bearing aims at the displaced point
+// at the hop midpoint, i.e. the great-circle bearing plus
+// atan(−g·h / (D/2)) for a path of length D, with h = F2LayerHeightKm. A
+// layer rising to the right therefore turns the bearing to the left
+// (counter-clockwise). A zero gradient, or coincident stations, gives the
+// great-circle bearing.
+func TiltCorrectedBearing(from, to Station, tiltGradient float64) float64 {
+	bearing := InitialBearing(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	halfPath := GreatCircleDistance(from, to) / 2
+	if tiltGradient == 0 || halfPath == 0 {
+		return bearing
+	}
+	return normalizeBearing(bearing + rad2deg(math.Atan2(-tiltGradient*F2LayerHeightKm, halfPath)))
+}
+
+// GreatCirclePolyline returns points [latitude, longitude] vertices, in
+// decimal degrees, evenly spaced along the great circle from one station to
+// another, the first and last being the stations themselves. Longitudes are
+// in (-180, 180]. Where the path crosses the antimeridian, a pair of extra
+// vertices at longitudes 180 and -180 (in the direction of travel) is
+// inserted at the crossing latitude, so plotters and GeoJSON writers can
+// split the line there. Only the missing one is inserted when a station lies
+// on the antimeridian. The result is nil if points is less than 2.
+func GreatCirclePolyline(from, to Station, points int) [][2]float64 {
+	if points < 2 {
+		return nil
+	}
+	line := make([][2]float64, 0, points)
+	for i := 0; i < points; i++ {
+		var v [2]float64
+		switch i {
+		case 0:
+			v = [2]float64{from.Latitude, normalizeLongitude(from.Longitude)}
+		case points - 1:
+			v = [2]float64{to.Latitude, normalizeLongitude(to.Longitude)}
+		default:
+			v = intermediatePoint(from, to, float64(i)/float64(points-1))
+		}
+		if i > 0 {
+			line = append(line, antimeridianCrossing(line[len(line)-1], v)...)
+		}
+		line = append(line, v)
+	}
+	return line
+}
+
+// intermediatePoint returns the [latitude, longitude] in decimal degrees of
+// the point a fraction of the way along the great circle from one station to
+// another. Longitudes are in (-180, 180].
+func intermediatePoint(from, to Station, fraction float64) [2]float64 {
+	d := fraction * deg2rad(CentralAngle(from.Latitude, from.Longitude, to.Latitude, to.Longitude))
+	bearing := deg2rad(InitialBearing(from.Latitude, from.Longitude, to.Latitude, to.Longitude))
+	phi1, lambda1 := deg2rad(from.Latitude), deg2rad(from.Longitude)
+	phi := math.Asin(math.Sin(phi1)*math.Cos(d) + math.Cos(phi1)*math.Sin(d)*math.Cos(bearing))
+	lambda := lambda1 + math.Atan2(math.Sin(bearing)*math.Sin(d)*math.Cos(phi1), math.Cos(d)-math.Sin(phi1)*math.Sin(phi))
+	return [2]float64{rad2deg(phi), normalizeLongitude(rad2deg(lambda))}
+}
+
+// antimeridianCrossing returns the two vertices, at longitude ±180 on the
+// side of p and q respectively, where the great-circle segment from p to q
+// crosses the antimeridian. A vertex is left out when p or q already lies
+// on the antimeridian on its side. It returns nil if the segment does not
+// cross.
+func antimeridianCrossing(p, q [2]float64) [][2]float64 {
+	if math.Abs(q[1]-p[1]) <= 180 {
+		return nil
+	}
+	side := 180.0
+	if p[1] < 0 {
+		side = -180
+	}
+	// An end point on the antimeridian is the crossing itself.
+	switch {
+	case p[1] == side:
+		return [][2]float64{{p[0], -side}}
+	case q[1] == -side:
+		return [][2]float64{{q[0], side}}
+	}
+	phiP, phiQ := deg2rad(p[0]), deg2rad(q[0])
+	lambdaP, lambdaQ := deg2rad(p[1]), deg2rad(q[1])
+	// Latitude of the great circle through p and q at longitude 180.
+	lat := rad2deg(math.Atan((math.Sin(phiP)*math.Cos(phiQ)*math.Sin(math.Pi-lambdaQ) -
+		math.Sin(phiQ)*math.Cos(phiP)*math.Sin(math.Pi-lambdaP)) /
+		(math.Cos(phiP) * math.Cos(phiQ) * math.Sin(lambdaP-lambdaQ))))
+	return [][2]float64{{lat, side}, {lat, -side}}
+}
@@ -0,0 +1,145 @@
+package hfprop
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewStation(t *testing.T) {
+	home := NewStation("home", 57.7, 11.97)
+	if !home.IsPseudo() || home.URSICode != "" {
+		t.Errorf("NewStation = %+v, want a pseudo-station", home)
+	}
+	if (Station{Name: "Juliusruh", URSICode: "JR055"}).IsPseudo() {
+		t.Error("a station with an URSI code is not a pseudo-station")
+	}
+	tests := []struct {
+		name string
+		to   Station
+		km   float64
+	}{
+		{"itself", home, 0},
+		{"one degree north", NewStation("north", 58.7, 11.97), 40000.0 / 360},
+		{"antipode", NewStation("antipode", -57.7, -168.03), 20000},
+	}
+	for _, tt := range tests {
+		if got := GreatCircleDistance(home, tt.to); !near(got, tt.km, 1e-6) {
+			t.Errorf("%s: GreatCircleDistance = %v, want %v", tt.name, got, tt.km)
+		}
+	}
+}
+
+func TestMagneticBearing(t *testing.T) {
+	origin := NewStation("o", 0, 0)
+	tests := []struct {
+		name        string
+		to          Station
+		declination float64
+		want        float64
+	}{
+		{"no declination", NewStation("e", 0, 10), 0, 90},
+		{"east declination", NewStation("e", 0, 10), 10, 80},
+		{"west declination", NewStation("e", 0, 10), -10, 100},
+		{"wraps below 0", NewStation("n", 10, 0), 5, 355},
+		{"wraps above 360", NewStation("w", 0, -10), -100, 10},
+		{"full turn", NewStation("e", 0, 10), 360, 90},
+	}
+	for _, tt := range tests {
+		if got := MagneticBearing(origin, tt.to, tt.declination); !near(got, tt.want, 1e-9) {
+			t.Errorf("%s: MagneticBearing = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	from, to := NewStation("gbg", 57.7, 11.97), NewStation("nyc", 40.7, -74.0)
+	trueBearing := InitialBearing(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	if got := MagneticBearing(from, to, 0); got != trueBearing {
+		t.Errorf("zero declination: %v, want true bearing %v", got, trueBearing)
+	}
+}
+
+// bearingDiff returns b-a wrapped onto (-180, 180].
+func bearingDiff(a, b float64) float64 {
+	return normalizeLongitude(b - a)
+}
+
+func TestTiltCorrectedBearing(t *testing.T) {
+	from, to := NewStation("gbg", 57.7, 11.97), NewStation("nyc", 40.7, -74.0)
+	trueBearing := InitialBearing(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	if got := TiltCorrectedBearing(from, to, 0); got != trueBearing {
+		t.Errorf("zero gradient: %v, want InitialBearing %v", got, trueBearing)
+	}
+	tests := []struct {
+		name     string
+		gradient float64
+		sign     float64
+	}{
+		{"rising to the right turns left", 0.05, -1},
+		{"rising to the left turns right", -0.05, 1},
+	}
+	for _, tt := range tests {
+		got := TiltCorrectedBearing(from, to, tt.gradient)
+		if d := bearingDiff(trueBearing, got); d*tt.sign <= 0 || d*tt.sign > 5 {
+			t.Errorf("%s: shift %v degrees", tt.name, d)
+		}
+	}
+	north := NewStation("n", 10, 0)
+	if got := TiltCorrectedBearing(NewStation("o", 0, 0), north, 0.05); got < 350 || got >= 360 {
+		t.Errorf("northbound left turn should wrap below 360, got %v", got)
+	}
+}
+
+func TestGreatCirclePolyline(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Station
+		points   int
+		splits   int
+	}{
+		{"Gothenburg to New York", NewStation("gbg", 57.7, 11.97), NewStation("nyc", 40.7, -74.0), 20, 0},
+		{"Tokyo to San Francisco", NewStation("tyo", 35.7, 139.7), NewStation("sfo", 37.8, -122.4), 20, 1},
+		{"San Francisco to Tokyo", NewStation("sfo", 37.8, -122.4), NewStation("tyo", 35.7, 139.7), 20, 1},
+		{"ending on the antimeridian", NewStation("a", 35, -170), NewStation("b", 40, 180), 2, 1},
+		{"starting on the antimeridian", NewStation("b", 40, 180), NewStation("a", 35, -170), 2, 1},
+		{"vertex on the antimeridian", NewStation("a", 0, 170), NewStation("b", 0, -170), 3, 1},
+	}
+	for _, tt := range tests {
+		line := GreatCirclePolyline(tt.from, tt.to, tt.points)
+		first, last := line[0], line[len(line)-1]
+		if first != [2]float64{tt.from.Latitude, normalizeLongitude(tt.from.Longitude)} ||
+			last != [2]float64{tt.to.Latitude, normalizeLongitude(tt.to.Longitude)} {
+			t.Errorf("%s: end points %v, %v, want the stations", tt.name, first, last)
+		}
+		total := CentralAngle(tt.from.Latitude, tt.from.Longitude, tt.to.Latitude, tt.to.Longitude)
+		splits := 0
+		for i, v := range line {
+			// A vertex on the great circle splits it without detour.
+			if a, b := CentralAngle(tt.from.Latitude, tt.from.Longitude, v[0], v[1]),
+				CentralAngle(v[0], v[1], tt.to.Latitude, tt.to.Longitude); !near(a+b, total, 1e-6) {
+				t.Errorf("%s: vertex %d %v is off the great circle", tt.name, i, v)
+			}
+			if i == 0 {
+				continue
+			}
+			if v == line[i-1] {
+				t.Errorf("%s: duplicate vertex %d %v", tt.name, i, v)
+			}
+			if math.Abs(v[1]-line[i-1][1]) > 180 {
+				splits++
+				if math.Abs(v[1]) != 180 || math.Abs(line[i-1][1]) != 180 || v[0] != line[i-1][0] {
+					t.Errorf("%s: split %v to %v is not a ±180 pair", tt.name, line[i-1], v)
+				}
+			}
+		}
+		if splits != tt.splits {
+			t.Errorf("%s: %d antimeridian splits, want %d", tt.name, splits, tt.splits)
+		}
+	}
+	got := GreatCirclePolyline(NewStation("a", 35, -170), NewStation("b", 40, 180), 2)
+	if len(got) != 3 || got[1][1] != -180 || !near(got[1][0], 40, 1e-9) {
+		t.Errorf("ending on the antimeridian: %v, want [[35 -170] [40 -180] [40 180]]", got)
+	}
+	for _, points := range []int{-1, 0, 1} {
+		if got := GreatCirclePolyline(NewStation("a", 0, 0), NewStation("b", 0, 10), points); got != nil {
+			t.Errorf("GreatCirclePolyline with %d points = %v, want nil", points, got)
+		}
+	}
+}